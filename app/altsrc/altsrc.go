@@ -0,0 +1,101 @@
+// Package altsrc lets cli.Flag values be sourced from an external
+// configuration file (YAML, JSON or TOML), mirroring urfave/cli's own
+// altsrc package. Precedence is: CLI arg > env var > config file > default.
+package altsrc
+
+import "fmt"
+
+// InputSource is implemented by anything that can answer "what value
+// does this flag name have in the config file", independent of whether
+// that file was YAML, JSON or TOML.
+type InputSource interface {
+	// Source identifies the backing file, used in error messages.
+	Source() string
+
+	Int(name string) (value int, found bool, erro error)
+	Bool(name string) (value bool, found bool, erro error)
+	String(name string) (value string, found bool, erro error)
+	StringSlice(name string) (value []string, found bool, erro error)
+}
+
+type mapSource struct {
+	path   string
+	values map[string]interface{}
+}
+
+func (m *mapSource) Source() string {
+	return m.path
+}
+
+func (m *mapSource) lookup(name string) (interface{}, bool) {
+	v, ok := m.values[name]
+	return v, ok
+}
+
+func (m *mapSource) Int(name string) (int, bool, error) {
+	v, ok := m.lookup(name)
+	if !ok {
+		return 0, false, nil
+	}
+
+	switch n := v.(type) {
+	case int:
+		return n, true, nil
+	case int64:
+		return int(n), true, nil
+	case float64:
+		return int(n), true, nil
+	default:
+		return 0, true, fmt.Errorf("altsrc: %q is not an int in %s", name, m.path)
+	}
+}
+
+func (m *mapSource) Bool(name string) (bool, bool, error) {
+	v, ok := m.lookup(name)
+	if !ok {
+		return false, false, nil
+	}
+
+	b, isBool := v.(bool)
+	if !isBool {
+		return false, true, fmt.Errorf("altsrc: %q is not a bool in %s", name, m.path)
+	}
+	return b, true, nil
+}
+
+func (m *mapSource) String(name string) (string, bool, error) {
+	v, ok := m.lookup(name)
+	if !ok {
+		return "", false, nil
+	}
+
+	s, isString := v.(string)
+	if !isString {
+		return "", true, fmt.Errorf("altsrc: %q is not a string in %s", name, m.path)
+	}
+	return s, true, nil
+}
+
+func (m *mapSource) StringSlice(name string) ([]string, bool, error) {
+	v, ok := m.lookup(name)
+	if !ok {
+		return nil, false, nil
+	}
+
+	switch vals := v.(type) {
+	case string:
+		return []string{vals}, true, nil
+	case []interface{}:
+		out := make([]string, len(vals))
+		for i, item := range vals {
+			s, isString := item.(string)
+			if !isString {
+				return nil, true, fmt.Errorf("altsrc: %q is not a list of strings in %s", name, m.path)
+			}
+			out[i] = s
+		}
+		return out, true, nil
+	default:
+		return nil, true, fmt.Errorf("altsrc: %q is not a list of strings in %s", name, m.path)
+	}
+}
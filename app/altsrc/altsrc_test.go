@@ -0,0 +1,106 @@
+package altsrc
+
+import "testing"
+
+func TestMapSourceIntCoercesNumericTypes(t *testing.T) {
+	src := &mapSource{path: "test", values: map[string]interface{}{
+		"a": 4,
+		"b": int64(5),
+		"c": float64(6),
+		"d": "not a number",
+	}}
+
+	for key, want := range map[string]int{"a": 4, "b": 5, "c": 6} {
+		v, found, erro := src.Int(key)
+		if erro != nil {
+			t.Fatalf("Int(%q): unexpected error: %v", key, erro)
+		}
+		if !found || v != want {
+			t.Errorf("Int(%q) = %d, %v, want %d, true", key, v, found, want)
+		}
+	}
+
+	if _, _, erro := src.Int("d"); erro == nil {
+		t.Errorf("Int(%q): expected a type-mismatch error", "d")
+	}
+
+	if _, found, erro := src.Int("missing"); found || erro != nil {
+		t.Errorf("Int(missing) = found=%v, err=%v, want found=false, err=nil", found, erro)
+	}
+}
+
+func TestMapSourceBool(t *testing.T) {
+	src := &mapSource{path: "test", values: map[string]interface{}{
+		"on":  true,
+		"bad": "true",
+	}}
+
+	v, found, erro := src.Bool("on")
+	if erro != nil || !found || !v {
+		t.Errorf("Bool(on) = %v, %v, %v, want true, true, nil", v, found, erro)
+	}
+
+	if _, _, erro := src.Bool("bad"); erro == nil {
+		t.Errorf("Bool(bad): expected a type-mismatch error for a string value")
+	}
+
+	if _, found, erro := src.Bool("missing"); found || erro != nil {
+		t.Errorf("Bool(missing) = found=%v, err=%v, want found=false, err=nil", found, erro)
+	}
+}
+
+func TestMapSourceString(t *testing.T) {
+	src := &mapSource{path: "test", values: map[string]interface{}{
+		"host": "example.com",
+		"bad":  42,
+	}}
+
+	v, found, erro := src.String("host")
+	if erro != nil || !found || v != "example.com" {
+		t.Errorf("String(host) = %q, %v, %v, want %q, true, nil", v, found, erro, "example.com")
+	}
+
+	if _, _, erro := src.String("bad"); erro == nil {
+		t.Errorf("String(bad): expected a type-mismatch error for an int value")
+	}
+
+	if _, found, erro := src.String("missing"); found || erro != nil {
+		t.Errorf("String(missing) = found=%v, err=%v, want found=false, err=nil", found, erro)
+	}
+}
+
+func TestMapSourceStringSlice(t *testing.T) {
+	src := &mapSource{path: "test", values: map[string]interface{}{
+		"hosts":  []interface{}{"a.com", "b.com"},
+		"single": "solo.com",
+		"bad":    []interface{}{"a.com", 42},
+		"worse":  42,
+	}}
+
+	v, found, erro := src.StringSlice("hosts")
+	if erro != nil || !found || len(v) != 2 || v[0] != "a.com" || v[1] != "b.com" {
+		t.Errorf("StringSlice(hosts) = %v, %v, %v, want [a.com b.com], true, nil", v, found, erro)
+	}
+
+	v, found, erro = src.StringSlice("single")
+	if erro != nil || !found || len(v) != 1 || v[0] != "solo.com" {
+		t.Errorf("StringSlice(single) = %v, %v, %v, want [solo.com], true, nil", v, found, erro)
+	}
+
+	if _, _, erro := src.StringSlice("bad"); erro == nil {
+		t.Errorf("StringSlice(bad): expected a type-mismatch error for a mixed-type list")
+	}
+	if _, _, erro := src.StringSlice("worse"); erro == nil {
+		t.Errorf("StringSlice(worse): expected a type-mismatch error for a non-list value")
+	}
+
+	if _, found, erro := src.StringSlice("missing"); found || erro != nil {
+		t.Errorf("StringSlice(missing) = found=%v, err=%v, want found=false, err=nil", found, erro)
+	}
+}
+
+func TestNewSourceRejectsUnrecognizedExtension(t *testing.T) {
+	if _, erro := NewSource("config.ini"); erro == nil {
+		t.Errorf("expected an error for an unrecognized extension")
+	}
+}
@@ -0,0 +1,108 @@
+package altsrc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// NewSource picks the InputSource implementation based on path's
+// extension (.yaml/.yml, .json or .toml).
+func NewSource(path string) (InputSource, error) {
+	switch extOf(path) {
+	case ".yaml", ".yml":
+		return NewYAMLSource(path)
+	case ".json":
+		return NewJSONSource(path)
+	case ".toml":
+		return NewTOMLSource(path)
+	default:
+		return nil, fmt.Errorf("altsrc: unrecognized config file extension for %s", path)
+	}
+}
+
+func extOf(path string) string {
+	for i := len(path) - 1; i >= 0 && path[i] != '/'; i-- {
+		if path[i] == '.' {
+			return path[i:]
+		}
+	}
+	return ""
+}
+
+// ApplyInputSourceValues fills any flag in flags that was not set on
+// the command line or via environment variable with the corresponding
+// value from src, respecting the precedence: CLI arg > env var > config
+// file > default.
+func ApplyInputSourceValues(c *cli.Context, src InputSource, flags []cli.Flag) error {
+	for _, f := range flags {
+		names := f.Names()
+		if len(names) == 0 {
+			continue
+		}
+		name := names[0]
+		if c.IsSet(name) {
+			continue
+		}
+
+		switch f.(type) {
+		case *cli.StringFlag:
+			v, found, erro := src.String(name)
+			if erro != nil {
+				return erro
+			}
+			if found {
+				if erro := c.Set(name, v); erro != nil {
+					return erro
+				}
+			}
+		case *cli.IntFlag:
+			v, found, erro := src.Int(name)
+			if erro != nil {
+				return erro
+			}
+			if found {
+				if erro := c.Set(name, strconv.Itoa(v)); erro != nil {
+					return erro
+				}
+			}
+		case *cli.BoolFlag:
+			v, found, erro := src.Bool(name)
+			if erro != nil {
+				return erro
+			}
+			if found && v {
+				if erro := c.Set(name, "true"); erro != nil {
+					return erro
+				}
+			}
+		case *cli.StringSliceFlag:
+			v, found, erro := src.StringSlice(name)
+			if erro != nil {
+				return erro
+			}
+			if found {
+				if erro := c.Set(name, strings.Join(v, ",")); erro != nil {
+					return erro
+				}
+			}
+		case *cli.DurationFlag:
+			v, found, erro := src.String(name)
+			if erro != nil {
+				return erro
+			}
+			if found {
+				if _, erro := time.ParseDuration(v); erro != nil {
+					return fmt.Errorf("altsrc: %q is not a valid duration in %s: %w", name, src.Source(), erro)
+				}
+				if erro := c.Set(name, v); erro != nil {
+					return erro
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package altsrc
+
+import (
+	"testing"
+
+	"github.com/urfave/cli/v2"
+)
+
+// runWithSource runs a minimal app so ApplyInputSourceValues sees a
+// real, fully-parsed *cli.Context (CLI args and env vars resolved by
+// the library itself, not hand-built).
+func runWithSource(t *testing.T, flags []cli.Flag, args []string, src InputSource) *cli.Context {
+	t.Helper()
+
+	var captured *cli.Context
+	app := &cli.App{
+		Name:  "test",
+		Flags: flags,
+		Action: func(c *cli.Context) error {
+			if erro := ApplyInputSourceValues(c, src, flags); erro != nil {
+				return erro
+			}
+			captured = c
+			return nil
+		},
+	}
+
+	if erro := app.Run(append([]string{"test"}, args...)); erro != nil {
+		t.Fatalf("app.Run: %v", erro)
+	}
+	return captured
+}
+
+func TestApplyInputSourceValuesFillsUnsetFlags(t *testing.T) {
+	flags := []cli.Flag{
+		&cli.StringFlag{Name: "host"},
+		&cli.IntFlag{Name: "workers"},
+	}
+	src := &mapSource{path: "test", values: map[string]interface{}{
+		"host":    "config.example.com",
+		"workers": 9,
+	}}
+
+	c := runWithSource(t, flags, nil, src)
+
+	if got := c.String("host"); got != "config.example.com" {
+		t.Errorf("host = %q, want config.example.com", got)
+	}
+	if got := c.Int("workers"); got != 9 {
+		t.Errorf("workers = %d, want 9", got)
+	}
+}
+
+func TestApplyInputSourceValuesPrefersCLIArgOverConfig(t *testing.T) {
+	flags := []cli.Flag{&cli.StringFlag{Name: "host"}}
+	src := &mapSource{path: "test", values: map[string]interface{}{"host": "config.example.com"}}
+
+	c := runWithSource(t, flags, []string{"--host", "cli.example.com"}, src)
+
+	if got := c.String("host"); got != "cli.example.com" {
+		t.Errorf("host = %q, want cli.example.com (CLI arg should win)", got)
+	}
+}
+
+func TestApplyInputSourceValuesPrefersEnvVarOverConfig(t *testing.T) {
+	flags := []cli.Flag{&cli.StringFlag{Name: "host", EnvVars: []string{"DNSCLI_TEST_HOST"}}}
+	src := &mapSource{path: "test", values: map[string]interface{}{"host": "config.example.com"}}
+
+	t.Setenv("DNSCLI_TEST_HOST", "env.example.com")
+
+	c := runWithSource(t, flags, nil, src)
+
+	if got := c.String("host"); got != "env.example.com" {
+		t.Errorf("host = %q, want env.example.com (env var should win over config)", got)
+	}
+}
+
+func TestApplyInputSourceValuesLeavesDefaultWhenConfigHasNoValue(t *testing.T) {
+	flags := []cli.Flag{&cli.StringFlag{Name: "host", Value: "default.example.com"}}
+	src := &mapSource{path: "test", values: map[string]interface{}{}}
+
+	c := runWithSource(t, flags, nil, src)
+
+	if got := c.String("host"); got != "default.example.com" {
+		t.Errorf("host = %q, want default.example.com", got)
+	}
+}
@@ -0,0 +1,22 @@
+package altsrc
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// NewJSONSource reads path as JSON and returns an InputSource backed
+// by its top-level keys.
+func NewJSONSource(path string) (InputSource, error) {
+	raw, erro := os.ReadFile(path)
+	if erro != nil {
+		return nil, erro
+	}
+
+	values := map[string]interface{}{}
+	if erro := json.Unmarshal(raw, &values); erro != nil {
+		return nil, erro
+	}
+
+	return &mapSource{path: path, values: values}, nil
+}
@@ -0,0 +1,23 @@
+package altsrc
+
+import (
+	"os"
+
+	"github.com/BurntSushi/toml"
+)
+
+// NewTOMLSource reads path as TOML and returns an InputSource backed
+// by its top-level keys.
+func NewTOMLSource(path string) (InputSource, error) {
+	raw, erro := os.ReadFile(path)
+	if erro != nil {
+		return nil, erro
+	}
+
+	values := map[string]interface{}{}
+	if erro := toml.Unmarshal(raw, &values); erro != nil {
+		return nil, erro
+	}
+
+	return &mapSource{path: path, values: values}, nil
+}
@@ -0,0 +1,30 @@
+package altsrc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewTOMLSourceRoundTrips(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	content := "host = \"example.com\"\nworkers = 8\nhosts = [\"a.example.com\", \"b.example.com\"]\n"
+	if erro := os.WriteFile(path, []byte(content), 0o644); erro != nil {
+		t.Fatalf("writing fixture: %v", erro)
+	}
+
+	src, erro := NewTOMLSource(path)
+	if erro != nil {
+		t.Fatalf("NewTOMLSource: %v", erro)
+	}
+
+	if v, found, erro := src.String("host"); erro != nil || !found || v != "example.com" {
+		t.Errorf("String(host) = %q, %v, %v, want example.com, true, nil", v, found, erro)
+	}
+	if v, found, erro := src.Int("workers"); erro != nil || !found || v != 8 {
+		t.Errorf("Int(workers) = %d, %v, %v, want 8, true, nil", v, found, erro)
+	}
+	if v, found, erro := src.StringSlice("hosts"); erro != nil || !found || len(v) != 2 {
+		t.Errorf("StringSlice(hosts) = %v, %v, %v, want 2 entries", v, found, erro)
+	}
+}
@@ -0,0 +1,23 @@
+package altsrc
+
+import (
+	"os"
+
+	"gopkg.in/yaml.v2"
+)
+
+// NewYAMLSource reads path as YAML and returns an InputSource backed
+// by its top-level keys.
+func NewYAMLSource(path string) (InputSource, error) {
+	raw, erro := os.ReadFile(path)
+	if erro != nil {
+		return nil, erro
+	}
+
+	values := map[string]interface{}{}
+	if erro := yaml.Unmarshal(raw, &values); erro != nil {
+		return nil, erro
+	}
+
+	return &mapSource{path: path, values: values}, nil
+}
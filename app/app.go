@@ -1,60 +1,403 @@
 package app
 
 import (
+	"context"
 	"fmt"
-	"log"
-	"net"
+	"os"
+	"time"
 
-	"github.com/urfave/cli"
+	"cli/app/altsrc"
+	"cli/app/cache"
+	"cli/app/dns"
+	"cli/app/lookup"
+
+	"github.com/urfave/cli/v2"
+)
+
+// configFlag loads flag values from a file and is registered on every
+// lookup command (not just the app itself), since urfave/cli v2 stops parsing
+// global flags once it reaches a subcommand token.
+var configFlag = &cli.StringFlag{
+	Name:  "config",
+	Usage: "load flag values from a YAML, JSON or TOML file",
+}
+
+// dnsFlags select how a command's Resolver reaches the network,
+// shared by every lookup subcommand.
+var dnsFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  "dns-server",
+		Usage: "query this host:port (a DoH URL with --protocol https) instead of the system resolver",
+	},
+	&cli.StringFlag{
+		Name:  "protocol",
+		Value: "udp",
+		Usage: "protocol to reach --dns-server with: udp, tcp, tls or https",
+	},
+}
+
+// commandFlags builds the flag set shared by every lookup subcommand:
+// the hosts to look up (--host/-H/--hosts-file), how many to run at
+// once (--workers), how to render them (--output) and how to reach the
+// network (--dns-server/--protocol). defaultHost, if non-empty, seeds
+// --host so the command still does something useful unadorned.
+func commandFlags(defaultHost string) []cli.Flag {
+	hostFlag := &cli.StringSliceFlag{
+		Name:    "host",
+		Aliases: []string{"H"},
+		EnvVars: []string{"DNSCLI_HOST"},
+	}
+	if defaultHost != "" {
+		hostFlag.Value = cli.NewStringSlice(defaultHost)
+	}
+
+	flags := []cli.Flag{
+		configFlag,
+		hostFlag,
+		&cli.StringFlag{
+			Name:  "hosts-file",
+			Usage: "newline-delimited file of hosts to look up",
+		},
+		&cli.IntFlag{
+			Name:  "workers",
+			Value: 4,
+			Usage: "number of concurrent lookup workers",
+		},
+		&cli.StringFlag{
+			Name:  "output",
+			Value: "text",
+			Usage: "output format: text, json, csv or ndjson",
+		},
+	}
+	return append(flags, dnsFlags...)
+}
+
+// cacheFlags control the result cache used by ip/server, the two
+// commands most often invoked repeatedly for the same hosts.
+var cacheFlags = []cli.Flag{
+	&cli.BoolFlag{
+		Name:  "no-cache",
+		Usage: "skip the cache and always query the network",
+	},
+	&cli.DurationFlag{
+		Name:  "cache-ttl",
+		Value: 5 * time.Minute,
+		Usage: "how long a cached result stays valid",
+	},
+	&cli.StringFlag{
+		Name:  "cache-backend",
+		Value: "disk",
+		Usage: "cache backend to use: disk (persists across runs) or memory (per-run LRU)",
+	},
+	&cli.IntFlag{
+		Name:  "cache-size",
+		Value: 256,
+		Usage: "max entries held by the memory cache backend",
+	},
+}
+
+var ipFlags = append(commandFlags("google.com"), cacheFlags...)
+var serverFlags = append(commandFlags(""), cacheFlags...)
+var reverseFlags = commandFlags("")
+var mxFlags = commandFlags("")
+var txtFlags = commandFlags("")
+var cnameFlags = commandFlags("")
+var srvFlags = append(commandFlags(""),
+	&cli.StringFlag{Name: "service", Value: "http"},
+	&cli.StringFlag{Name: "proto", Value: "tcp"},
 )
 
+// loadConfig returns a cli.BeforeFunc that fills flags from the file
+// named by the global --config flag, if one was given. CLI args and
+// env vars still win over whatever the config file says.
+func loadConfig(flags []cli.Flag) cli.BeforeFunc {
+	return func(c *cli.Context) error {
+		path := c.String("config")
+		if path == "" {
+			return nil
+		}
+
+		src, erro := altsrc.NewSource(path)
+		if erro != nil {
+			return erro
+		}
+
+		return altsrc.ApplyInputSourceValues(c, src, flags)
+	}
+}
+
+// completeFlags returns a cli.BashCompleteFunc listing flags' long
+// (and, for single-letter aliases, short) forms for shell completion.
+func completeFlags(flags []cli.Flag) func(c *cli.Context) {
+	return func(c *cli.Context) {
+		for _, f := range flags {
+			for _, name := range f.Names() {
+				if len(name) == 1 {
+					fmt.Println("-" + name)
+				} else {
+					fmt.Println("--" + name)
+				}
+			}
+		}
+	}
+}
+
+// resolverFor builds the dns.Resolver a command should query through,
+// honoring --dns-server and --protocol.
+func resolverFor(c *cli.Context) (dns.Resolver, error) {
+	return dns.New(dns.Config{
+		Server:   c.String("dns-server"),
+		Protocol: c.String("protocol"),
+	})
+}
+
+// runDNSLookup collects the command's hosts, resolves them concurrently
+// through fn and renders the results, aggregating per-host failures
+// into the returned error instead of aborting the whole run.
+func runDNSLookup(c *cli.Context, fn func(ctx context.Context, r dns.Resolver, host string) ([]string, error)) error {
+	hosts, erro := collectHosts(c)
+	if erro != nil {
+		return erro
+	}
+
+	resolver, erro := resolverFor(c)
+	if erro != nil {
+		return erro
+	}
+
+	ctx := context.Background()
+	pool := lookup.NewResolver(c.Int("workers"))
+	results := pool.Run(hosts, func(host string) ([]string, error) {
+		return fn(ctx, resolver, host)
+	})
+
+	if erro := lookup.WriteResults(os.Stdout, c.String("output"), results); erro != nil {
+		return erro
+	}
+
+	return lookup.Errors(results)
+}
+
+// cacheFor builds the Cache backend named by --cache-backend: "disk"
+// (the default, persists across runs) or "memory" (a per-run LRU
+// bounded by --cache-size).
+func cacheFor(c *cli.Context) (cache.Cache, error) {
+	switch backend := c.String("cache-backend"); backend {
+	case "", "disk":
+		return cache.NewDisk()
+	case "memory":
+		return cache.NewMemory(c.Int("cache-size")), nil
+	default:
+		return nil, fmt.Errorf("unknown --cache-backend %q: want disk or memory", backend)
+	}
+}
+
+// runCachedDNSLookup behaves like runDNSLookup, but first checks the
+// cache for each host and, on a miss, saves the fresh result there
+// under cache-ttl. --no-cache disables both.
+func runCachedDNSLookup(c *cli.Context, keyPrefix string, fn func(ctx context.Context, r dns.Resolver, host string) ([]string, error)) error {
+	hosts, erro := collectHosts(c)
+	if erro != nil {
+		return erro
+	}
+
+	resolver, erro := resolverFor(c)
+	if erro != nil {
+		return erro
+	}
+
+	var store cache.Cache
+	if !c.Bool("no-cache") {
+		store, erro = cacheFor(c)
+		if erro != nil {
+			return erro
+		}
+	}
+	ttl := c.Duration("cache-ttl")
+
+	ctx := context.Background()
+	pool := lookup.NewResolver(c.Int("workers"))
+	results := pool.Run(hosts, func(host string) ([]string, error) {
+		key := keyPrefix + ":" + host
+
+		if store != nil {
+			if values, ok := store.Get(key); ok {
+				return values, nil
+			}
+		}
+
+		values, erro := fn(ctx, resolver, host)
+		if erro != nil {
+			return nil, erro
+		}
+
+		if store != nil {
+			if erro := store.Set(key, values, ttl); erro != nil {
+				fmt.Fprintf(os.Stderr, "cache: %v\n", erro)
+			}
+		}
+		return values, nil
+	})
+
+	if erro := lookup.WriteResults(os.Stdout, c.String("output"), results); erro != nil {
+		return erro
+	}
+
+	return lookup.Errors(results)
+}
+
+func ipAction(c *cli.Context) error {
+	return runCachedDNSLookup(c, "ip", func(ctx context.Context, r dns.Resolver, host string) ([]string, error) {
+		return r.Host(ctx, host)
+	})
+}
+
+func serverAction(c *cli.Context) error {
+	return runCachedDNSLookup(c, "server", func(ctx context.Context, r dns.Resolver, host string) ([]string, error) {
+		return r.NS(ctx, host)
+	})
+}
+
+func reverseAction(c *cli.Context) error {
+	return runDNSLookup(c, func(ctx context.Context, r dns.Resolver, host string) ([]string, error) {
+		return r.Reverse(ctx, host)
+	})
+}
+
+func mxAction(c *cli.Context) error {
+	return runDNSLookup(c, func(ctx context.Context, r dns.Resolver, host string) ([]string, error) {
+		return r.MX(ctx, host)
+	})
+}
+
+func txtAction(c *cli.Context) error {
+	return runDNSLookup(c, func(ctx context.Context, r dns.Resolver, host string) ([]string, error) {
+		return r.TXT(ctx, host)
+	})
+}
+
+func cnameAction(c *cli.Context) error {
+	return runDNSLookup(c, func(ctx context.Context, r dns.Resolver, host string) ([]string, error) {
+		return r.CNAME(ctx, host)
+	})
+}
+
+func srvAction(c *cli.Context) error {
+	service := c.String("service")
+	proto := c.String("proto")
+	return runDNSLookup(c, func(ctx context.Context, r dns.Resolver, host string) ([]string, error) {
+		return r.SRV(ctx, service, proto, host)
+	})
+}
+
+func cachePurgeAction(c *cli.Context) error {
+	store, erro := cache.NewDisk()
+	if erro != nil {
+		return erro
+	}
+	if erro := store.Purge(); erro != nil {
+		return erro
+	}
+	fmt.Println("cache purged")
+	return nil
+}
+
+func cacheStatsAction(c *cli.Context) error {
+	store, erro := cache.NewDisk()
+	if erro != nil {
+		return erro
+	}
+	stats, erro := store.Stats()
+	if erro != nil {
+		return erro
+	}
+	fmt.Printf("%d entries cached in %s\n", stats.Entries, stats.Path)
+	return nil
+}
+
 func Generate() *cli.App {
 	app := cli.NewApp()
 	app.Name = "A golang simples command line interface"
 	app.Usage = "Search for IPs and servers name on the web"
+	app.Version = "0.1.0"
+	app.Authors = []*cli.Author{
+		{Name: "duarty"},
+	}
+	app.EnableBashCompletion = true
 
-	app.Commands = []cli.Command{
-		{
-			Name:  "ip",
-			Usage: "Search for IP address",
-			Flags: []cli.Flag{
-				cli.StringFlag{
-					Name:  "host",
-					Value: "google.com",
-				},
-			},
-			Action: func(c *cli.Context) {
-				host := c.String("host")
-
-				ips, erro := net.LookupIP(host)
-				if erro != nil {
-					log.Fatal(erro)
-				}
+	app.Flags = []cli.Flag{configFlag}
 
-				for _, ip := range ips {
-					fmt.Println(ip)
-				}
-			},
+	app.Commands = []*cli.Command{
+		{
+			Name:         "ip",
+			Usage:        "Search for IP address",
+			Flags:        ipFlags,
+			Before:       loadConfig(ipFlags),
+			Action:       ipAction,
+			BashComplete: completeFlags(ipFlags),
+		},
+		{
+			Name:         "server",
+			Usage:        "Search for server name",
+			Flags:        serverFlags,
+			Before:       loadConfig(serverFlags),
+			Action:       serverAction,
+			BashComplete: completeFlags(serverFlags),
+		},
+		{
+			Name:         "reverse",
+			Usage:        "Reverse lookup the hostnames pointing at an IP address",
+			Flags:        reverseFlags,
+			Before:       loadConfig(reverseFlags),
+			Action:       reverseAction,
+			BashComplete: completeFlags(reverseFlags),
+		},
+		{
+			Name:         "mx",
+			Usage:        "Search for mail exchange records",
+			Flags:        mxFlags,
+			Before:       loadConfig(mxFlags),
+			Action:       mxAction,
+			BashComplete: completeFlags(mxFlags),
+		},
+		{
+			Name:         "txt",
+			Usage:        "Search for TXT records",
+			Flags:        txtFlags,
+			Before:       loadConfig(txtFlags),
+			Action:       txtAction,
+			BashComplete: completeFlags(txtFlags),
+		},
+		{
+			Name:         "cname",
+			Usage:        "Search for the canonical name of a host",
+			Flags:        cnameFlags,
+			Before:       loadConfig(cnameFlags),
+			Action:       cnameAction,
+			BashComplete: completeFlags(cnameFlags),
+		},
+		{
+			Name:         "srv",
+			Usage:        "Search for service (SRV) records",
+			Flags:        srvFlags,
+			Before:       loadConfig(srvFlags),
+			Action:       srvAction,
+			BashComplete: completeFlags(srvFlags),
 		},
 		{
-			Name:  "server",
-			Usage: "Search for server name",
-			Flags: []cli.Flag{
-				cli.StringFlag{
-					Name: "host",
+			Name:  "cache",
+			Usage: "Inspect or clear the on-disk lookup cache",
+			Subcommands: []*cli.Command{
+				{
+					Name:   "purge",
+					Usage:  "Delete every cached entry",
+					Action: cachePurgeAction,
+				},
+				{
+					Name:   "stats",
+					Usage:  "Show how many entries are cached and where",
+					Action: cacheStatsAction,
 				},
-			},
-			Action: func(c *cli.Context) {
-				host := c.String("host")
-
-				servers, erro := net.LookupNS(host)
-				if erro != nil {
-					log.Fatal(erro)
-				}
-
-				for _, server := range servers {
-					fmt.Println(*server)
-				}
 			},
 		},
 	}
@@ -0,0 +1,26 @@
+// Package cache provides a small Get/Set-with-TTL cache so repeated
+// lookups within a record's TTL can be served without touching the
+// network, plus the purge/stats helpers behind the `cache` subcommand.
+package cache
+
+import "time"
+
+// Cache stores lookup results keyed by caller-chosen strings (e.g.
+// "ip:example.com"), each valid for the TTL it was Set with.
+type Cache interface {
+	// Get returns the cached value for key, or ok=false if it is
+	// missing or has expired.
+	Get(key string) (value []string, ok bool)
+	// Set stores value under key for the given ttl.
+	Set(key string, value []string, ttl time.Duration) error
+	// Purge removes every entry.
+	Purge() error
+	// Stats reports how the cache is doing right now.
+	Stats() (Stats, error)
+}
+
+// Stats summarizes the current state of a Cache, for `cache stats`.
+type Stats struct {
+	Entries int
+	Path    string
+}
@@ -0,0 +1,125 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type diskEntry struct {
+	Value     []string  `json:"value"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Disk is a JSON-file-backed Cache under $XDG_CACHE_HOME/dnscli (or
+// ~/.cache/dnscli), so results survive across CLI invocations.
+type Disk struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewDisk builds a Disk cache backed by the default dnscli cache file.
+func NewDisk() (*Disk, error) {
+	path, erro := defaultCachePath()
+	if erro != nil {
+		return nil, erro
+	}
+	return &Disk{path: path}, nil
+}
+
+func defaultCachePath() (string, error) {
+	dir := os.Getenv("XDG_CACHE_HOME")
+	if dir == "" {
+		home, erro := os.UserHomeDir()
+		if erro != nil {
+			return "", erro
+		}
+		dir = filepath.Join(home, ".cache")
+	}
+	return filepath.Join(dir, "dnscli", "cache.json"), nil
+}
+
+func (d *Disk) load() (map[string]diskEntry, error) {
+	raw, erro := os.ReadFile(d.path)
+	if os.IsNotExist(erro) {
+		return map[string]diskEntry{}, nil
+	}
+	if erro != nil {
+		return nil, erro
+	}
+
+	entries := map[string]diskEntry{}
+	if erro := json.Unmarshal(raw, &entries); erro != nil {
+		return nil, erro
+	}
+	return entries, nil
+}
+
+func (d *Disk) save(entries map[string]diskEntry) error {
+	if erro := os.MkdirAll(filepath.Dir(d.path), 0o755); erro != nil {
+		return erro
+	}
+
+	raw, erro := json.MarshalIndent(entries, "", "  ")
+	if erro != nil {
+		return erro
+	}
+	return os.WriteFile(d.path, raw, 0o644)
+}
+
+func (d *Disk) Get(key string) ([]string, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entries, erro := d.load()
+	if erro != nil {
+		return nil, false
+	}
+
+	entry, ok := entries[key]
+	if !ok || time.Now().After(entry.ExpiresAt) {
+		return nil, false
+	}
+	return entry.Value, true
+}
+
+func (d *Disk) Set(key string, value []string, ttl time.Duration) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entries, erro := d.load()
+	if erro != nil {
+		return erro
+	}
+
+	entries[key] = diskEntry{Value: value, ExpiresAt: time.Now().Add(ttl)}
+	return d.save(entries)
+}
+
+func (d *Disk) Purge() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.save(map[string]diskEntry{})
+}
+
+func (d *Disk) Stats() (Stats, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entries, erro := d.load()
+	if erro != nil {
+		return Stats{}, erro
+	}
+
+	now := time.Now()
+	live := 0
+	for _, entry := range entries {
+		if now.Before(entry.ExpiresAt) {
+			live++
+		}
+	}
+	return Stats{Entries: live, Path: d.path}, nil
+}
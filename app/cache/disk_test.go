@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestDisk(t *testing.T) *Disk {
+	t.Helper()
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	d, erro := NewDisk()
+	if erro != nil {
+		t.Fatalf("NewDisk returned error: %v", erro)
+	}
+	return d
+}
+
+func TestDiskGetSetRoundTrip(t *testing.T) {
+	d := newTestDisk(t)
+
+	if _, ok := d.Get("missing"); ok {
+		t.Fatalf("expected missing key to not be found")
+	}
+
+	if erro := d.Set("a", []string{"1.2.3.4"}, time.Minute); erro != nil {
+		t.Fatalf("Set returned error: %v", erro)
+	}
+
+	values, ok := d.Get("a")
+	if !ok {
+		t.Fatalf("expected key %q to be found", "a")
+	}
+	if len(values) != 1 || values[0] != "1.2.3.4" {
+		t.Errorf("Get(%q) = %v, want [1.2.3.4]", "a", values)
+	}
+}
+
+func TestDiskExpiresEntries(t *testing.T) {
+	d := newTestDisk(t)
+
+	if erro := d.Set("a", []string{"1.2.3.4"}, -time.Second); erro != nil {
+		t.Fatalf("Set returned error: %v", erro)
+	}
+
+	if _, ok := d.Get("a"); ok {
+		t.Errorf("expected expired entry to not be found")
+	}
+}
+
+func TestDiskPurgeRemovesEntries(t *testing.T) {
+	d := newTestDisk(t)
+
+	d.Set("a", []string{"a"}, time.Minute)
+	d.Set("b", []string{"b"}, time.Minute)
+
+	if erro := d.Purge(); erro != nil {
+		t.Fatalf("Purge returned error: %v", erro)
+	}
+
+	if _, ok := d.Get("a"); ok {
+		t.Errorf("expected a to have been purged")
+	}
+	if _, ok := d.Get("b"); ok {
+		t.Errorf("expected b to have been purged")
+	}
+}
+
+func TestDiskStatsCountsLiveEntries(t *testing.T) {
+	d := newTestDisk(t)
+
+	d.Set("a", []string{"a"}, time.Minute)
+	d.Set("b", []string{"b"}, -time.Second) // already expired
+
+	stats, erro := d.Stats()
+	if erro != nil {
+		t.Fatalf("Stats returned error: %v", erro)
+	}
+	if stats.Entries != 1 {
+		t.Errorf("Stats().Entries = %d, want 1 (expired entries should not count)", stats.Entries)
+	}
+	if stats.Path != d.path {
+		t.Errorf("Stats().Path = %q, want %q", stats.Path, d.path)
+	}
+}
@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type memoryEntry struct {
+	key       string
+	value     []string
+	expiresAt time.Time
+}
+
+// Memory is an in-memory, size-bounded LRU Cache.
+type Memory struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List
+}
+
+// NewMemory builds a Memory cache holding at most capacity entries,
+// evicting the least recently used one once full. capacity <= 0 falls
+// back to a sensible default.
+func NewMemory(capacity int) *Memory {
+	if capacity <= 0 {
+		capacity = 256
+	}
+	return &Memory{
+		capacity: capacity,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (m *Memory) Get(key string) ([]string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := el.Value.(*memoryEntry)
+	if time.Now().After(entry.expiresAt) {
+		m.order.Remove(el)
+		delete(m.items, key)
+		return nil, false
+	}
+
+	m.order.MoveToFront(el)
+	return entry.value, true
+}
+
+func (m *Memory) Set(key string, value []string, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := &memoryEntry{key: key, value: value, expiresAt: time.Now().Add(ttl)}
+
+	if el, ok := m.items[key]; ok {
+		el.Value = entry
+		m.order.MoveToFront(el)
+		return nil
+	}
+
+	m.items[key] = m.order.PushFront(entry)
+
+	if m.order.Len() > m.capacity {
+		oldest := m.order.Back()
+		if oldest != nil {
+			m.order.Remove(oldest)
+			delete(m.items, oldest.Value.(*memoryEntry).key)
+		}
+	}
+	return nil
+}
+
+func (m *Memory) Purge() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.items = make(map[string]*list.Element)
+	m.order.Init()
+	return nil
+}
+
+func (m *Memory) Stats() (Stats, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return Stats{Entries: len(m.items), Path: "memory"}, nil
+}
@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryGetSetRoundTrip(t *testing.T) {
+	m := NewMemory(2)
+
+	if _, ok := m.Get("missing"); ok {
+		t.Fatalf("expected missing key to not be found")
+	}
+
+	if erro := m.Set("a", []string{"1.2.3.4"}, time.Minute); erro != nil {
+		t.Fatalf("Set returned error: %v", erro)
+	}
+
+	values, ok := m.Get("a")
+	if !ok {
+		t.Fatalf("expected key %q to be found", "a")
+	}
+	if len(values) != 1 || values[0] != "1.2.3.4" {
+		t.Errorf("Get(%q) = %v, want [1.2.3.4]", "a", values)
+	}
+}
+
+func TestMemoryExpiresEntries(t *testing.T) {
+	m := NewMemory(2)
+
+	if erro := m.Set("a", []string{"1.2.3.4"}, -time.Second); erro != nil {
+		t.Fatalf("Set returned error: %v", erro)
+	}
+
+	if _, ok := m.Get("a"); ok {
+		t.Errorf("expected expired entry to not be found")
+	}
+}
+
+func TestMemoryEvictsLeastRecentlyUsed(t *testing.T) {
+	m := NewMemory(2)
+
+	m.Set("a", []string{"a"}, time.Minute)
+	m.Set("b", []string{"b"}, time.Minute)
+	m.Get("a") // touch a so b becomes the least recently used
+	m.Set("c", []string{"c"}, time.Minute)
+
+	if _, ok := m.Get("b"); ok {
+		t.Errorf("expected b to have been evicted")
+	}
+	if _, ok := m.Get("a"); !ok {
+		t.Errorf("expected a to still be cached")
+	}
+	if _, ok := m.Get("c"); !ok {
+		t.Errorf("expected c to be cached")
+	}
+}
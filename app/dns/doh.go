@@ -0,0 +1,179 @@
+package dns
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// dohResolver answers queries by POSTing DNS wire-format messages to a
+// DNS-over-HTTPS endpoint (RFC 8484).
+type dohResolver struct {
+	url    string
+	client *http.Client
+}
+
+func newDoHResolver(url string) (Resolver, error) {
+	if url == "" {
+		return nil, fmt.Errorf("dns: --dns-server is required for the https protocol")
+	}
+	return &dohResolver{url: url, client: http.DefaultClient}, nil
+}
+
+func (r *dohResolver) query(ctx context.Context, name string, qtype uint16) (*dns.Msg, error) {
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(name), qtype)
+
+	packed, erro := msg.Pack()
+	if erro != nil {
+		return nil, erro
+	}
+
+	req, erro := http.NewRequestWithContext(ctx, http.MethodPost, r.url, bytes.NewReader(packed))
+	if erro != nil {
+		return nil, erro
+	}
+	req.Header.Set("Content-Type", "application/dns-message")
+	req.Header.Set("Accept", "application/dns-message")
+
+	resp, erro := r.client.Do(req)
+	if erro != nil {
+		return nil, erro
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("dns: DoH query to %s failed: %s", r.url, resp.Status)
+	}
+
+	body, erro := io.ReadAll(resp.Body)
+	if erro != nil {
+		return nil, erro
+	}
+
+	reply := new(dns.Msg)
+	if erro := reply.Unpack(body); erro != nil {
+		return nil, erro
+	}
+	return reply, nil
+}
+
+func (r *dohResolver) Host(ctx context.Context, host string) ([]string, error) {
+	var values []string
+	for _, qtype := range []uint16{dns.TypeA, dns.TypeAAAA} {
+		reply, erro := r.query(ctx, host, qtype)
+		if erro != nil {
+			return nil, erro
+		}
+		for _, rr := range reply.Answer {
+			switch rec := rr.(type) {
+			case *dns.A:
+				values = append(values, rec.A.String())
+			case *dns.AAAA:
+				values = append(values, rec.AAAA.String())
+			}
+		}
+	}
+	return values, nil
+}
+
+func (r *dohResolver) NS(ctx context.Context, host string) ([]string, error) {
+	reply, erro := r.query(ctx, host, dns.TypeNS)
+	if erro != nil {
+		return nil, erro
+	}
+
+	var values []string
+	for _, rr := range reply.Answer {
+		if ns, ok := rr.(*dns.NS); ok {
+			values = append(values, ns.Ns)
+		}
+	}
+	return values, nil
+}
+
+func (r *dohResolver) Reverse(ctx context.Context, addr string) ([]string, error) {
+	name, erro := dns.ReverseAddr(addr)
+	if erro != nil {
+		return nil, erro
+	}
+
+	reply, erro := r.query(ctx, name, dns.TypePTR)
+	if erro != nil {
+		return nil, erro
+	}
+
+	var values []string
+	for _, rr := range reply.Answer {
+		if ptr, ok := rr.(*dns.PTR); ok {
+			values = append(values, ptr.Ptr)
+		}
+	}
+	return values, nil
+}
+
+func (r *dohResolver) MX(ctx context.Context, host string) ([]string, error) {
+	reply, erro := r.query(ctx, host, dns.TypeMX)
+	if erro != nil {
+		return nil, erro
+	}
+
+	var values []string
+	for _, rr := range reply.Answer {
+		if mx, ok := rr.(*dns.MX); ok {
+			values = append(values, fmt.Sprintf("%d %s", mx.Preference, mx.Mx))
+		}
+	}
+	return values, nil
+}
+
+func (r *dohResolver) TXT(ctx context.Context, host string) ([]string, error) {
+	reply, erro := r.query(ctx, host, dns.TypeTXT)
+	if erro != nil {
+		return nil, erro
+	}
+
+	var values []string
+	for _, rr := range reply.Answer {
+		if txt, ok := rr.(*dns.TXT); ok {
+			values = append(values, strings.Join(txt.Txt, ""))
+		}
+	}
+	return values, nil
+}
+
+func (r *dohResolver) CNAME(ctx context.Context, host string) ([]string, error) {
+	reply, erro := r.query(ctx, host, dns.TypeCNAME)
+	if erro != nil {
+		return nil, erro
+	}
+
+	var values []string
+	for _, rr := range reply.Answer {
+		if cname, ok := rr.(*dns.CNAME); ok {
+			values = append(values, cname.Target)
+		}
+	}
+	return values, nil
+}
+
+func (r *dohResolver) SRV(ctx context.Context, service, proto, name string) ([]string, error) {
+	qname := fmt.Sprintf("_%s._%s.%s", service, proto, dns.Fqdn(name))
+	reply, erro := r.query(ctx, qname, dns.TypeSRV)
+	if erro != nil {
+		return nil, erro
+	}
+
+	var values []string
+	for _, rr := range reply.Answer {
+		if srv, ok := rr.(*dns.SRV); ok {
+			values = append(values, fmt.Sprintf("%d %d %d %s", srv.Priority, srv.Weight, srv.Port, srv.Target))
+		}
+	}
+	return values, nil
+}
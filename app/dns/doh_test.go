@@ -0,0 +1,222 @@
+package dns
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/miekg/dns"
+)
+
+// newDoHTestServer starts a DoH endpoint that unpacks the posted query
+// and hands it to build, which returns the canned reply to pack back.
+func newDoHTestServer(t *testing.T, build func(q *dns.Msg) *dns.Msg) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, erro := io.ReadAll(r.Body)
+		if erro != nil {
+			t.Fatalf("reading request body: %v", erro)
+		}
+
+		q := new(dns.Msg)
+		if erro := q.Unpack(body); erro != nil {
+			t.Fatalf("unpacking query: %v", erro)
+		}
+
+		reply := build(q)
+		packed, erro := reply.Pack()
+		if erro != nil {
+			t.Fatalf("packing reply: %v", erro)
+		}
+
+		w.Header().Set("Content-Type", "application/dns-message")
+		w.Write(packed)
+	}))
+}
+
+func rrOrFatal(t *testing.T, s string) dns.RR {
+	t.Helper()
+
+	rr, erro := dns.NewRR(s)
+	if erro != nil {
+		t.Fatalf("building RR %q: %v", s, erro)
+	}
+	return rr
+}
+
+func TestDoHResolverHost(t *testing.T) {
+	srv := newDoHTestServer(t, func(q *dns.Msg) *dns.Msg {
+		reply := new(dns.Msg)
+		reply.SetReply(q)
+		if q.Question[0].Qtype == dns.TypeA {
+			reply.Answer = append(reply.Answer, rrOrFatal(t, q.Question[0].Name+" 300 IN A 93.184.216.34"))
+		}
+		return reply
+	})
+	defer srv.Close()
+
+	r, erro := newDoHResolver(srv.URL)
+	if erro != nil {
+		t.Fatalf("newDoHResolver: %v", erro)
+	}
+
+	values, erro := r.Host(context.Background(), "example.com")
+	if erro != nil {
+		t.Fatalf("Host: %v", erro)
+	}
+	if len(values) != 1 || values[0] != "93.184.216.34" {
+		t.Errorf("Host() = %v, want [93.184.216.34]", values)
+	}
+}
+
+func TestDoHResolverMX(t *testing.T) {
+	srv := newDoHTestServer(t, func(q *dns.Msg) *dns.Msg {
+		reply := new(dns.Msg)
+		reply.SetReply(q)
+		reply.Answer = append(reply.Answer, rrOrFatal(t, q.Question[0].Name+" 300 IN MX 10 mail.example.com."))
+		return reply
+	})
+	defer srv.Close()
+
+	r, erro := newDoHResolver(srv.URL)
+	if erro != nil {
+		t.Fatalf("newDoHResolver: %v", erro)
+	}
+
+	values, erro := r.MX(context.Background(), "example.com")
+	if erro != nil {
+		t.Fatalf("MX: %v", erro)
+	}
+	if len(values) != 1 || values[0] != "10 mail.example.com." {
+		t.Errorf("MX() = %v, want [10 mail.example.com.]", values)
+	}
+}
+
+func TestDoHResolverTXT(t *testing.T) {
+	srv := newDoHTestServer(t, func(q *dns.Msg) *dns.Msg {
+		reply := new(dns.Msg)
+		reply.SetReply(q)
+		rr := new(dns.TXT)
+		rr.Hdr = dns.RR_Header{Name: q.Question[0].Name, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: 300}
+		rr.Txt = []string{"v=spf1 ", "include:_spf.example.com ", "~all"}
+		reply.Answer = append(reply.Answer, rr)
+		return reply
+	})
+	defer srv.Close()
+
+	r, erro := newDoHResolver(srv.URL)
+	if erro != nil {
+		t.Fatalf("newDoHResolver: %v", erro)
+	}
+
+	values, erro := r.TXT(context.Background(), "example.com")
+	if erro != nil {
+		t.Fatalf("TXT: %v", erro)
+	}
+	want := "v=spf1 include:_spf.example.com ~all"
+	if len(values) != 1 || values[0] != want {
+		t.Errorf("TXT() = %v, want [%s]", values, want)
+	}
+}
+
+func TestDoHResolverCNAME(t *testing.T) {
+	srv := newDoHTestServer(t, func(q *dns.Msg) *dns.Msg {
+		reply := new(dns.Msg)
+		reply.SetReply(q)
+		reply.Answer = append(reply.Answer, rrOrFatal(t, q.Question[0].Name+" 300 IN CNAME target.example.com."))
+		return reply
+	})
+	defer srv.Close()
+
+	r, erro := newDoHResolver(srv.URL)
+	if erro != nil {
+		t.Fatalf("newDoHResolver: %v", erro)
+	}
+
+	values, erro := r.CNAME(context.Background(), "www.example.com")
+	if erro != nil {
+		t.Fatalf("CNAME: %v", erro)
+	}
+	if len(values) != 1 || values[0] != "target.example.com." {
+		t.Errorf("CNAME() = %v, want [target.example.com.]", values)
+	}
+}
+
+func TestDoHResolverSRVBuildsServiceProtoName(t *testing.T) {
+	var gotName string
+
+	srv := newDoHTestServer(t, func(q *dns.Msg) *dns.Msg {
+		gotName = q.Question[0].Name
+
+		reply := new(dns.Msg)
+		reply.SetReply(q)
+		reply.Answer = append(reply.Answer, rrOrFatal(t, q.Question[0].Name+" 300 IN SRV 10 20 5060 sip.example.com."))
+		return reply
+	})
+	defer srv.Close()
+
+	r, erro := newDoHResolver(srv.URL)
+	if erro != nil {
+		t.Fatalf("newDoHResolver: %v", erro)
+	}
+
+	values, erro := r.SRV(context.Background(), "sip", "tcp", "example.com")
+	if erro != nil {
+		t.Fatalf("SRV: %v", erro)
+	}
+
+	if want := "_sip._tcp.example.com."; gotName != want {
+		t.Errorf("queried name = %q, want %q", gotName, want)
+	}
+	if len(values) != 1 || values[0] != "10 20 5060 sip.example.com." {
+		t.Errorf("SRV() = %v, want [10 20 5060 sip.example.com.]", values)
+	}
+}
+
+func TestDoHResolverReverse(t *testing.T) {
+	srv := newDoHTestServer(t, func(q *dns.Msg) *dns.Msg {
+		reply := new(dns.Msg)
+		reply.SetReply(q)
+		reply.Answer = append(reply.Answer, rrOrFatal(t, q.Question[0].Name+" 300 IN PTR host.example.com."))
+		return reply
+	})
+	defer srv.Close()
+
+	r, erro := newDoHResolver(srv.URL)
+	if erro != nil {
+		t.Fatalf("newDoHResolver: %v", erro)
+	}
+
+	values, erro := r.Reverse(context.Background(), "93.184.216.34")
+	if erro != nil {
+		t.Fatalf("Reverse: %v", erro)
+	}
+	if len(values) != 1 || values[0] != "host.example.com." {
+		t.Errorf("Reverse() = %v, want [host.example.com.]", values)
+	}
+}
+
+func TestDoHResolverNonOKStatusIsAnError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	r, erro := newDoHResolver(srv.URL)
+	if erro != nil {
+		t.Fatalf("newDoHResolver: %v", erro)
+	}
+
+	if _, erro := r.Host(context.Background(), "example.com"); erro == nil {
+		t.Errorf("expected an error for a non-200 DoH response")
+	}
+}
+
+func TestNewDoHResolverRequiresURL(t *testing.T) {
+	if _, erro := newDoHResolver(""); erro == nil {
+		t.Errorf("expected an error when no DoH URL is given")
+	}
+}
@@ -0,0 +1,49 @@
+// Package dns provides a pluggable Resolver abstraction so the CLI's
+// lookup commands can run over plain DNS (UDP/TCP), DNS-over-TLS or
+// DNS-over-HTTPS, instead of being hard-wired to the OS resolver.
+package dns
+
+import (
+	"context"
+	"fmt"
+)
+
+// Resolver answers the record types the CLI exposes as subcommands.
+type Resolver interface {
+	Host(ctx context.Context, host string) ([]string, error)
+	NS(ctx context.Context, host string) ([]string, error)
+	Reverse(ctx context.Context, addr string) ([]string, error)
+	MX(ctx context.Context, host string) ([]string, error)
+	TXT(ctx context.Context, host string) ([]string, error)
+	CNAME(ctx context.Context, host string) ([]string, error)
+	SRV(ctx context.Context, service, proto, name string) ([]string, error)
+}
+
+// Config selects how a Resolver reaches the network.
+type Config struct {
+	// Server is a host:port to query directly, bypassing the system
+	// resolver configuration; for the "https" protocol it is the DoH
+	// endpoint URL instead. Empty means "use the OS default", except
+	// for "https", which requires it.
+	Server string
+	// Protocol is one of "udp", "tcp", "tls" or "https". Empty means "udp".
+	Protocol string
+}
+
+// New builds a Resolver for cfg, dispatching to a DoH client for the
+// "https" protocol and to a net.Resolver-backed client otherwise.
+func New(cfg Config) (Resolver, error) {
+	protocol := cfg.Protocol
+	if protocol == "" {
+		protocol = "udp"
+	}
+
+	switch protocol {
+	case "udp", "tcp", "tls":
+		return newStdResolver(cfg.Server, protocol)
+	case "https":
+		return newDoHResolver(cfg.Server)
+	default:
+		return nil, fmt.Errorf("dns: unsupported protocol %q", protocol)
+	}
+}
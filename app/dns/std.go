@@ -0,0 +1,112 @@
+package dns
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+)
+
+// stdResolver answers queries via net.Resolver, optionally pointed at
+// a specific server and dialed over plain DNS (UDP/TCP) or DoT.
+type stdResolver struct {
+	resolver *net.Resolver
+}
+
+func newStdResolver(server, protocol string) (Resolver, error) {
+	if server == "" {
+		return &stdResolver{resolver: net.DefaultResolver}, nil
+	}
+
+	dialer := &net.Dialer{}
+	dial := func(ctx context.Context, network, _ string) (net.Conn, error) {
+		switch protocol {
+		case "tls":
+			return tls.DialWithDialer(dialer, "tcp", server, &tls.Config{ServerName: hostOnly(server)})
+		case "tcp":
+			return dialer.DialContext(ctx, "tcp", server)
+		default:
+			return dialer.DialContext(ctx, "udp", server)
+		}
+	}
+
+	return &stdResolver{
+		resolver: &net.Resolver{PreferGo: true, Dial: dial},
+	}, nil
+}
+
+func hostOnly(hostport string) string {
+	host, _, erro := net.SplitHostPort(hostport)
+	if erro != nil {
+		return hostport
+	}
+	return host
+}
+
+func (r *stdResolver) Host(ctx context.Context, host string) ([]string, error) {
+	ips, erro := r.resolver.LookupIPAddr(ctx, host)
+	if erro != nil {
+		return nil, erro
+	}
+
+	values := make([]string, len(ips))
+	for i, ip := range ips {
+		values[i] = ip.String()
+	}
+	return values, nil
+}
+
+func (r *stdResolver) NS(ctx context.Context, host string) ([]string, error) {
+	servers, erro := r.resolver.LookupNS(ctx, host)
+	if erro != nil {
+		return nil, erro
+	}
+
+	values := make([]string, len(servers))
+	for i, server := range servers {
+		values[i] = server.Host
+	}
+	return values, nil
+}
+
+func (r *stdResolver) Reverse(ctx context.Context, addr string) ([]string, error) {
+	return r.resolver.LookupAddr(ctx, addr)
+}
+
+func (r *stdResolver) MX(ctx context.Context, host string) ([]string, error) {
+	records, erro := r.resolver.LookupMX(ctx, host)
+	if erro != nil {
+		return nil, erro
+	}
+
+	values := make([]string, len(records))
+	for i, rec := range records {
+		values[i] = fmt.Sprintf("%d %s", rec.Pref, rec.Host)
+	}
+	return values, nil
+}
+
+func (r *stdResolver) TXT(ctx context.Context, host string) ([]string, error) {
+	return r.resolver.LookupTXT(ctx, host)
+}
+
+func (r *stdResolver) CNAME(ctx context.Context, host string) ([]string, error) {
+	cname, erro := r.resolver.LookupCNAME(ctx, host)
+	if erro != nil {
+		return nil, erro
+	}
+	return []string{cname}, nil
+}
+
+func (r *stdResolver) SRV(ctx context.Context, service, proto, name string) ([]string, error) {
+	_, records, erro := r.resolver.LookupSRV(ctx, service, proto, name)
+	if erro != nil {
+		return nil, erro
+	}
+
+	values := make([]string, len(records))
+	for i, rec := range records {
+		values[i] = fmt.Sprintf("%d %d %d %s", rec.Priority, rec.Weight, rec.Port, rec.Target)
+	}
+	return values, nil
+}
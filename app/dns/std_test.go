@@ -0,0 +1,17 @@
+package dns
+
+import "testing"
+
+func TestHostOnly(t *testing.T) {
+	cases := map[string]string{
+		"1.1.1.1:53":         "1.1.1.1",
+		"dns.example.com:53": "dns.example.com",
+		"not-a-hostport":     "not-a-hostport",
+	}
+
+	for in, want := range cases {
+		if got := hostOnly(in); got != want {
+			t.Errorf("hostOnly(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
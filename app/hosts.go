@@ -0,0 +1,52 @@
+package app
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// readHostsFile reads newline-delimited hostnames from path, skipping
+// blank lines.
+func readHostsFile(path string) ([]string, error) {
+	f, erro := os.Open(path)
+	if erro != nil {
+		return nil, erro
+	}
+	defer f.Close()
+
+	var hosts []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		host := strings.TrimSpace(scanner.Text())
+		if host == "" {
+			continue
+		}
+		hosts = append(hosts, host)
+	}
+	return hosts, scanner.Err()
+}
+
+// collectHosts merges the repeated --host flag values with any hosts
+// found in --hosts-file. It errors out if the merged list is empty,
+// rather than letting the command silently resolve nothing.
+func collectHosts(c *cli.Context) ([]string, error) {
+	hosts := append([]string{}, c.StringSlice("host")...)
+
+	if path := c.String("hosts-file"); path != "" {
+		fromFile, erro := readHostsFile(path)
+		if erro != nil {
+			return nil, erro
+		}
+		hosts = append(hosts, fromFile...)
+	}
+
+	if len(hosts) == 0 {
+		return nil, fmt.Errorf("no hosts specified: pass --host or --hosts-file")
+	}
+
+	return hosts, nil
+}
@@ -0,0 +1,94 @@
+package lookup
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteResults renders results to w in the given format: text, json,
+// csv or ndjson. Per-host errors are included inline rather than
+// aborting the whole render.
+func WriteResults(w io.Writer, format string, results []Result) error {
+	switch format {
+	case "", "text":
+		return writeText(w, results)
+	case "json":
+		return writeJSON(w, results)
+	case "csv":
+		return writeCSV(w, results)
+	case "ndjson":
+		return writeNDJSON(w, results)
+	default:
+		return fmt.Errorf("lookup: unknown output format %q", format)
+	}
+}
+
+func writeText(w io.Writer, results []Result) error {
+	for _, res := range results {
+		if res.Err != nil {
+			fmt.Fprintf(w, "%s: error: %v\n", res.Host, res.Err)
+			continue
+		}
+		for _, v := range res.Values {
+			fmt.Fprintf(w, "%s: %s\n", res.Host, v)
+		}
+	}
+	return nil
+}
+
+type record struct {
+	Host   string   `json:"host"`
+	Values []string `json:"values,omitempty"`
+	Error  string   `json:"error,omitempty"`
+}
+
+func toRecords(results []Result) []record {
+	records := make([]record, len(results))
+	for i, res := range results {
+		rec := record{Host: res.Host, Values: res.Values}
+		if res.Err != nil {
+			rec.Error = res.Err.Error()
+		}
+		records[i] = rec
+	}
+	return records
+}
+
+func writeJSON(w io.Writer, results []Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(toRecords(results))
+}
+
+func writeNDJSON(w io.Writer, results []Result) error {
+	enc := json.NewEncoder(w)
+	for _, rec := range toRecords(results) {
+		if erro := enc.Encode(rec); erro != nil {
+			return erro
+		}
+	}
+	return nil
+}
+
+func writeCSV(w io.Writer, results []Result) error {
+	cw := csv.NewWriter(w)
+	if erro := cw.Write([]string{"host", "values", "error"}); erro != nil {
+		return erro
+	}
+
+	for _, res := range results {
+		errStr := ""
+		if res.Err != nil {
+			errStr = res.Err.Error()
+		}
+		if erro := cw.Write([]string{res.Host, strings.Join(res.Values, "|"), errStr}); erro != nil {
+			return erro
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,77 @@
+// Package lookup runs DNS lookups concurrently across many hosts and
+// renders the results in a handful of machine- and human-readable
+// formats.
+package lookup
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// LookupFunc resolves a single host, returning the raw record values
+// found (IPs, nameservers, …) as strings.
+type LookupFunc func(host string) ([]string, error)
+
+// Result is the outcome of looking up one host.
+type Result struct {
+	Host   string
+	Values []string
+	Err    error
+}
+
+// Resolver runs a LookupFunc across many hosts using a fixed pool of
+// workers, so one slow or failing host doesn't block the rest.
+type Resolver struct {
+	Workers int
+}
+
+// NewResolver builds a Resolver with the given worker count, falling
+// back to a single worker if workers is not positive.
+func NewResolver(workers int) *Resolver {
+	if workers < 1 {
+		workers = 1
+	}
+	return &Resolver{Workers: workers}
+}
+
+// Run looks up every host in hosts via fn, returning one Result per
+// host in the same order hosts were given.
+func (r *Resolver) Run(hosts []string, fn LookupFunc) []Result {
+	jobs := make(chan int)
+	results := make([]Result, len(hosts))
+
+	var wg sync.WaitGroup
+	for i := 0; i < r.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				host := hosts[idx]
+				values, erro := fn(host)
+				results[idx] = Result{Host: host, Values: values, Err: erro}
+			}
+		}()
+	}
+
+	for i := range hosts {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// Errors collects the per-host errors out of results into a single
+// error, or nil if every lookup succeeded.
+func Errors(results []Result) error {
+	var erro *multierror.Error
+	for _, res := range results {
+		if res.Err != nil {
+			erro = multierror.Append(erro, fmt.Errorf("%s: %w", res.Host, res.Err))
+		}
+	}
+	return erro.ErrorOrNil()
+}
@@ -0,0 +1,55 @@
+package lookup
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestResolverRunPreservesOrderAndIsolatesErrors(t *testing.T) {
+	hosts := []string{"a.test", "b.test", "c.test"}
+
+	resolver := NewResolver(2)
+	results := resolver.Run(hosts, func(host string) ([]string, error) {
+		if host == "b.test" {
+			return nil, errors.New("boom")
+		}
+		return []string{host + "-value"}, nil
+	})
+
+	if len(results) != len(hosts) {
+		t.Fatalf("got %d results, want %d", len(results), len(hosts))
+	}
+
+	for i, host := range hosts {
+		if results[i].Host != host {
+			t.Errorf("result %d host = %q, want %q", i, results[i].Host, host)
+		}
+	}
+
+	if results[1].Err == nil {
+		t.Errorf("expected b.test to have an error")
+	}
+	if results[0].Err != nil || results[2].Err != nil {
+		t.Errorf("expected a.test and c.test to succeed")
+	}
+}
+
+func TestErrorsAggregatesPerHostFailures(t *testing.T) {
+	results := []Result{
+		{Host: "ok.test", Values: []string{"1.2.3.4"}},
+		{Host: "bad.test", Err: errors.New("not found")},
+	}
+
+	erro := Errors(results)
+	if erro == nil {
+		t.Fatal("expected an aggregated error")
+	}
+	if got := erro.Error(); !strings.Contains(got, "bad.test") {
+		t.Errorf("aggregated error %q does not mention bad.test", got)
+	}
+
+	if Errors([]Result{{Host: "ok.test"}}) != nil {
+		t.Errorf("expected nil error when every lookup succeeds")
+	}
+}